@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/padurean/pandemia-covid/pkg/countries"
+)
+
+// defaultCountryCodes is used when neither --countries nor --config is given.
+var defaultCountryCodes = []string{"ROU", "DEU", "ITA", "DNK"}
+
+// config is the shape of the optional --config JSON file.
+type config struct {
+	Countries []string `json:"countries"`
+}
+
+// resolveCountries figures out the desired country codes from the
+// --countries flag, falling back to the --config file and finally to
+// defaultCountryCodes, then validates and resolves each code through the
+// countries registry.
+func resolveCountries(countriesFlag, configFlag string) (map[CountryCode]CountryName, error) {
+	codes, err := countryCodesFromFlags(countriesFlag, configFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	return countryNamesForCodes(codes)
+}
+
+// countryNamesForCodes validates each code against the countries registry
+// and resolves its Romanian display name.
+func countryNamesForCodes(codes []string) (map[CountryCode]CountryName, error) {
+	result := make(map[CountryCode]CountryName, len(codes))
+	for _, code := range codes {
+		if !countries.CheckCountryCode(code) {
+			return nil, fmt.Errorf("unknown country code %q", code)
+		}
+
+		name, ok := countries.GetCountryName(code, countries.LocaleRomanian)
+		if !ok {
+			return nil, fmt.Errorf("no Romanian name registered for country code %q", code)
+		}
+
+		result[CountryCode(code)] = CountryName(name)
+	}
+
+	return result, nil
+}
+
+func countryCodesFromFlags(countriesFlag, configFlag string) ([]string, error) {
+	if countriesFlag != "" {
+		var codes []string
+		for _, code := range strings.Split(countriesFlag, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				codes = append(codes, code)
+			}
+		}
+		return codes, nil
+	}
+
+	if configFlag != "" {
+		return loadCountryCodesFromConfig(configFlag)
+	}
+
+	return defaultCountryCodes, nil
+}
+
+func loadCountryCodesFromConfig(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file %s: %v", path, err)
+	}
+
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("error unmarshaling config file %s: %v", path, err)
+	}
+
+	return cfg.Countries, nil
+}
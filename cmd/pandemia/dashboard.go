@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/padurean/pandemia-covid/pkg/charts"
+	"github.com/padurean/pandemia-covid/pkg/stats"
+)
+
+// buildDashboard assembles the full multi-chart dashboard (headline totals,
+// smoothed 7-day averages, weekly totals and small multiples) from the
+// fetched per-country data.
+func buildDashboard(data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName) *charts.Dashboard {
+	codes := sortedCountryCodes(data)
+
+	return &charts.Dashboard{
+		Panels: []charts.Panel{
+			buildHeadlinePanel(data, countryNames, codes),
+			buildRollingAveragePanel(data, countryNames, codes),
+			buildWeeklyTotalsPanel(data, countryNames, codes),
+			buildSmallMultiplesPanel(data, countryNames, codes),
+		},
+	}
+}
+
+func sortedCountryCodes(data map[CountryCode]CountryData) []CountryCode {
+	codes := make([]CountryCode, 0, len(data))
+	for cc := range data {
+		codes = append(codes, cc)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
+
+// buildHeadlinePanel reads each country's all-time totals off its most
+// recent day, since TotalDeaths/TotalConfirmed/TotalTests/Recovered are
+// already cumulative figures, not rates to be summed across days.
+func buildHeadlinePanel(
+	data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName, codes []CountryCode,
+) charts.HeadlinePanel {
+	totals := make([]charts.HeadlineTotals, 0, len(codes))
+	for _, cc := range codes {
+		t := charts.HeadlineTotals{Country: string(countryNames[cc])}
+		if days := data[cc].Data; len(days) > 0 {
+			last := days[len(days)-1]
+			t.Deaths = last.TotalDeaths
+			t.Confirmed = last.TotalConfirmed
+			t.Tested = last.TotalTests
+			t.Recovered = last.Recovered
+		}
+		totals = append(totals, t)
+	}
+
+	return charts.HeadlinePanel{
+		Title:  "Pandemia cu și fără Valuri",
+		Totals: totals,
+	}
+}
+
+func buildRollingAveragePanel(
+	data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName, codes []CountryCode,
+) charts.LinePanel {
+	series := make([]charts.Series, 0, len(codes))
+	for _, cc := range codes {
+		dates, raw := datesAndDeaths(data[cc])
+		smoothed := stats.RollingAverage(raw, 7)
+
+		values := make(map[string]float32, len(dates))
+		for i, date := range dates {
+			values[date] = smoothed[i]
+		}
+		series = append(series, charts.Series{Name: string(countryNames[cc]), Values: values})
+	}
+
+	return charts.LinePanel{
+		Title:    "Pandemia cu și fără Valuri",
+		Subtitle: "Medie mobilă pe 7 zile - decese la 1 milion de locuitori",
+		Series:   series,
+	}
+}
+
+func buildWeeklyTotalsPanel(
+	data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName, codes []CountryCode,
+) charts.BarPanel {
+	series := make([]charts.Series, 0, len(codes))
+	for _, cc := range codes {
+		dates, raw := datesAndDeaths(data[cc])
+
+		values := make(map[string]float32, len(dates)/7+1)
+		for _, week := range stats.WeeklyTotals(dates, raw) {
+			values[week.WeekStart] = week.Total
+		}
+		series = append(series, charts.Series{Name: string(countryNames[cc]), Values: values})
+	}
+
+	return charts.BarPanel{
+		Title:    "Pandemia cu și fără Valuri",
+		Subtitle: "Totaluri săptămânale - decese la 1 milion de locuitori",
+		Series:   series,
+	}
+}
+
+func buildSmallMultiplesPanel(
+	data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName, codes []CountryCode,
+) charts.SmallMultiplesPanel {
+	panels := make([]charts.LinePanel, 0, len(codes))
+	for _, cc := range codes {
+		dates, raw := datesAndDeaths(data[cc])
+
+		values := make(map[string]float32, len(dates))
+		for i, date := range dates {
+			values[date] = raw[i]
+		}
+		panels = append(panels, charts.LinePanel{
+			Title:  string(countryNames[cc]),
+			Series: []charts.Series{{Name: string(countryNames[cc]), Values: values}},
+			Width:  "400px",
+			Height: "250px",
+		})
+	}
+
+	return charts.SmallMultiplesPanel{
+		Title:  "Pe țări",
+		Panels: panels,
+	}
+}
+
+func datesAndDeaths(cd CountryData) ([]string, []float32) {
+	dates := make([]string, 0, len(cd.Data))
+	values := make([]float32, 0, len(cd.Data))
+	for _, d := range cd.Data {
+		dates = append(dates, d.Date)
+		values = append(values, d.NewDeathsPerMillion)
+	}
+	return dates, values
+}
+
+// renderDashboard renders the full dashboard to pkg/charts/dashboard.html.
+func renderDashboard(data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName) error {
+	fmt.Println("rendering dashboard ...")
+
+	dashboard := buildDashboard(data, countryNames)
+
+	f, err := os.OpenFile("pkg/charts/dashboard.html", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening dashboard file for writing: %v", err)
+	}
+	defer f.Close()
+
+	return dashboard.Render(f)
+}
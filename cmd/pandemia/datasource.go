@@ -0,0 +1,62 @@
+package main
+
+// CountryCode ...
+type CountryCode string
+
+// CountryName ...
+type CountryName string
+
+// CountryData ...
+type CountryData struct {
+	Data []DayData `json:"data"`
+}
+
+// DayData is one day's figures for a single country, normalized to the
+// shared shape every DataSource fills in. Not every source populates every
+// field: see the per-field comments below for what's missing where and why.
+type DayData struct {
+	Date                string  `json:"date"`
+	NewDeathsPerMillion float32 `json:"new_deaths_per_million"`
+	NewCasesPerMillion  float32 `json:"new_cases_per_million"`
+	// NewTestsPerThousand mirrors OWID's own per-thousand testing rate
+	// (OWID never reports testing per-million). JHU's daily reports carry
+	// no testing data at all, so this is always zero for --source=jhu.
+	NewTestsPerThousand float32 `json:"new_tests_smoothed_per_thousand"`
+	// TotalDeaths and TotalConfirmed are cumulative, all-time counts: OWID's
+	// own total_deaths/total_cases for that day, or JHU's province-summed
+	// Deaths/Confirmed columns for its one daily snapshot.
+	TotalDeaths    float32 `json:"total_deaths"`
+	TotalConfirmed float32 `json:"total_cases"`
+	// TotalTests is OWID's cumulative total_tests. JHU has no equivalent.
+	TotalTests float32 `json:"total_tests"`
+	// Recovered and ActiveCases have no OWID equivalent (OWID dropped
+	// recovered/active-case reporting partway through the pandemic); only
+	// the JHU source, which still carries those CSV columns, populates them.
+	Recovered   float32 `json:"-"`
+	ActiveCases float32 `json:"-"`
+}
+
+// DataSource fetches per-country pandemic data from a particular upstream
+// (Our World In Data, Johns Hopkins CSSE, ...) and normalizes it to the
+// shared CountryData shape so the rendering code stays source-agnostic.
+type DataSource interface {
+	// Fetch returns data for the requested countries, trimmed to the last
+	// onlyLast entries per country when onlyLast > 0.
+	Fetch(countries map[CountryCode]CountryName, onlyLast int) (map[CountryCode]CountryData, error)
+}
+
+func trimToLast(data map[CountryCode]CountryData, onlyLast int) map[CountryCode]CountryData {
+	if onlyLast <= 0 {
+		return data
+	}
+
+	trimmed := make(map[CountryCode]CountryData, len(data))
+	for cc, cd := range data {
+		if len(cd.Data) > onlyLast {
+			cd.Data = cd.Data[len(cd.Data)-onlyLast:]
+		}
+		trimmed[cc] = cd
+	}
+
+	return trimmed
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/padurean/pandemia-covid/pkg/cache"
+)
+
+// jhuCacheTTL is how long a day's downloaded CSV is reused before refetching,
+// well under 24h since a "latest" report can still be revised upstream
+// shortly after it first appears.
+const jhuCacheTTL = 6 * time.Hour
+
+// jhuCountryNames maps our ISO-3 country codes to the "Country_Region" names
+// used in the JHU CSSE daily reports, which are free-text and don't follow
+// ISO-3166.
+var jhuCountryNames = map[CountryCode]string{
+	"ROU": "Romania",
+	"DEU": "Germany",
+	"ITA": "Italy",
+	"DNK": "Denmark",
+}
+
+var errJHUReportNotFound = errors.New("jhu: daily report not found")
+
+// JHUSource fetches the Johns Hopkins CSSE daily-report CSVs. Unlike OWID,
+// JHU publishes one file per day rather than a rolling time series, and a
+// given day's file can take a while to show up, so Fetch walks backwards
+// from today until it finds the latest available report.
+type JHUSource struct {
+	Cache       *cache.FileStore
+	BaseURL     string
+	MaxLookback int
+}
+
+// NewJHUSource returns a JHUSource backed by store, configured against the
+// public CSSEGISandData/COVID-19 GitHub mirror.
+func NewJHUSource(store *cache.FileStore) *JHUSource {
+	return &JHUSource{
+		Cache: store,
+		BaseURL: "https://raw.githubusercontent.com/CSSEGISandData/COVID-19/master/" +
+			"csse_covid_19_data/csse_covid_19_daily_reports",
+		MaxLookback: 14,
+	}
+}
+
+// Fetch implements DataSource.
+func (s *JHUSource) Fetch(countries map[CountryCode]CountryName, onlyLast int) (map[CountryCode]CountryData, error) {
+	rows, reportDate, err := s.findLatestDailyReport()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := aggregateJHURowsByCountry(rows, countries, reportDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return trimToLast(data, onlyLast), nil
+}
+
+// findLatestDailyReport walks backwards from today, day by day, until it
+// finds a daily report CSV that exists upstream, going through the cache so
+// repeated calls for the same day don't refetch.
+func (s *JHUSource) findLatestDailyReport() ([]jhuRow, time.Time, error) {
+	for daysBack := 0; daysBack <= s.MaxLookback; daysBack++ {
+		date := time.Now().AddDate(0, 0, -daysBack)
+
+		rows, err := s.fetchDailyReport(date)
+		if err == nil {
+			return rows, date, nil
+		}
+		if !errors.Is(err, errJHUReportNotFound) {
+			return nil, time.Time{}, err
+		}
+	}
+
+	return nil, time.Time{}, fmt.Errorf("jhu: no daily report found in the last %d days", s.MaxLookback)
+}
+
+func (s *JHUSource) fetchDailyReport(date time.Time) ([]jhuRow, error) {
+	url := fmt.Sprintf("%s/%s.csv", s.BaseURL, date.Format("01-02-2006"))
+
+	digest, _ := cache.RequestKey(url)
+	key := fmt.Sprintf("jhu/%s/%s", date.Format("2006-01-02"), digest)
+
+	if cached, _, ok := s.Cache.Get(key); ok {
+		return parseJHUCSV(bytes.NewReader(cached))
+	}
+
+	body, err := fetchJHUCSVBody(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Cache.Put(key, body, jhuCacheTTL); err != nil {
+		return nil, err
+	}
+
+	return parseJHUCSV(bytes.NewReader(body))
+}
+
+type jhuRow struct {
+	CountryRegion   string
+	Confirmed       float64
+	Deaths          float64
+	Recovered       float64
+	Active          float64
+	IncidentRate    float64
+	CaseFatalityPct float64
+}
+
+func fetchJHUCSVBody(url string) ([]byte, error) {
+	response, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading JHU report from URL %s: %v", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, errJHUReportNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading JHU report from URL %s: got status %s", url, response.Status)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JHU report response from URL %s: %v", url, err)
+	}
+
+	return body, nil
+}
+
+func parseJHUCSV(r io.Reader) ([]jhuRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading JHU report header: %v", err)
+	}
+
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[name] = i
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := col[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	var rows []jhuRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading JHU report row: %v", err)
+		}
+
+		rows = append(rows, jhuRow{
+			CountryRegion:   field(record, "Country_Region"),
+			Confirmed:       parseJHUFloat(field(record, "Confirmed")),
+			Deaths:          parseJHUFloat(field(record, "Deaths")),
+			Recovered:       parseJHUFloat(field(record, "Recovered")),
+			Active:          parseJHUFloat(field(record, "Active")),
+			IncidentRate:    parseJHUFloat(field(record, "Incident_Rate")),
+			CaseFatalityPct: parseJHUFloat(field(record, "Case_Fatality_Ratio")),
+		})
+	}
+
+	return rows, nil
+}
+
+func parseJHUFloat(s string) float64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// aggregateJHURowsByCountry sums the (possibly per-province) rows for each
+// requested country and normalizes the result to a single-day CountryData,
+// dated on the report's own date. It returns an error if a requested country
+// has no entry in jhuCountryNames, since silently reporting all-zero data for
+// an unmapped country would be indistinguishable from the country having
+// genuinely reported zero cases.
+func aggregateJHURowsByCountry(
+	rows []jhuRow, countries map[CountryCode]CountryName, reportDate time.Time,
+) (map[CountryCode]CountryData, error) {
+	type totals struct {
+		confirmed, deaths, recovered, active float64
+	}
+
+	byCountry := make(map[CountryCode]*totals, len(countries))
+	for cc := range countries {
+		if _, ok := jhuCountryNames[cc]; !ok {
+			return nil, fmt.Errorf("jhu: no Country_Region mapping for country code %q", cc)
+		}
+		byCountry[cc] = &totals{}
+	}
+
+	for _, row := range rows {
+		for cc, name := range jhuCountryNames {
+			t, wanted := byCountry[cc]
+			if !wanted || row.CountryRegion != name {
+				continue
+			}
+			t.confirmed += row.Confirmed
+			t.deaths += row.Deaths
+			t.recovered += row.Recovered
+			t.active += row.Active
+		}
+	}
+
+	date := reportDate.Format("2006-01-02")
+	data := make(map[CountryCode]CountryData, len(countries))
+	for cc, t := range byCountry {
+		data[cc] = CountryData{
+			Data: []DayData{{
+				Date:           date,
+				TotalDeaths:    float32(t.deaths),
+				TotalConfirmed: float32(t.confirmed),
+				Recovered:      float32(t.recovered),
+				ActiveCases:    float32(t.active),
+				// A JHU daily report is a single-day snapshot of cumulative
+				// totals, not a time series, so there's no prior day to diff
+				// against for a day-over-day new-cases or new-deaths figure.
+				// TotalDeaths/TotalConfirmed/Recovered/ActiveCases are
+				// themselves cumulative and so are well-defined from one
+				// snapshot, but New{Cases,Deaths}PerMillion and
+				// NewTestsPerThousand have no JHU equivalent and are left at
+				// zero rather than fabricated from the cumulative
+				// Incident_Rate/Case_Fatality_Ratio.
+			}},
+		}
+	}
+
+	return data, nil
+}
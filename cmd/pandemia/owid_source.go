@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/padurean/pandemia-covid/pkg/cache"
+)
+
+// owidCacheTTL is how long a cached OWID payload is served before a refetch
+// is attempted.
+const owidCacheTTL = 24 * time.Hour
+
+// owidCacheKey returns the cache key for the OWID payload fetched on date,
+// laid out as {source}/{yyyy-mm-dd}/{sha256(request)} like every other
+// source, even though OWID's fetch takes no parameters that vary the
+// response, so the request digest is always the same value.
+func owidCacheKey(date time.Time) string {
+	digest, _ := cache.RequestKey(struct{}{})
+	return fmt.Sprintf("owid/%s/%s", date.Format("2006-01-02"), digest)
+}
+
+// OWIDSource fetches the daily Our World In Data JSON feed through a shared
+// cache.FileStore, revalidating with the upstream ETag instead of
+// re-downloading the ~100MB payload when it hasn't changed.
+type OWIDSource struct {
+	Cache       *cache.FileStore
+	DownloadURL string
+}
+
+// NewOWIDSource returns an OWIDSource backed by store.
+func NewOWIDSource(store *cache.FileStore) *OWIDSource {
+	return &OWIDSource{
+		Cache:       store,
+		DownloadURL: "https://covid.ourworldindata.org/data/owid-covid-data.json",
+	}
+}
+
+// Fetch implements DataSource.
+func (s *OWIDSource) Fetch(countries map[CountryCode]CountryName, onlyLast int) (map[CountryCode]CountryData, error) {
+	key := owidCacheKey(time.Now())
+
+	if cached, _, ok := s.Cache.Get(key); ok {
+		if allData, err := decodeOWIDData(cached); err == nil && includesAllCountries(allData, countries) {
+			return trimToLast(filterCountries(allData, countries), onlyLast), nil
+		}
+	}
+
+	// Today's entry only carries an ETag if it was stored earlier today, so
+	// the first fetch of a day revalidates against yesterday's entry instead.
+	etagKey := key
+	_, etag, _, ok := s.Cache.Meta(etagKey)
+	if !ok {
+		etagKey = owidCacheKey(time.Now().AddDate(0, 0, -1))
+		_, etag, _, _ = s.Cache.Meta(etagKey)
+	}
+
+	allData, newETag, notModified, err := s.download(etag)
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyToCache []byte
+	if notModified {
+		raw, ok := s.Cache.Raw(etagKey)
+		if !ok {
+			return nil, fmt.Errorf("owid: upstream reported no change but no cached copy exists")
+		}
+		if allData, err = decodeOWIDData(raw); err != nil {
+			return nil, err
+		}
+		bodyToCache = raw
+	} else {
+		if bodyToCache, err = json.Marshal(allData); err != nil {
+			return nil, fmt.Errorf("error marshaling downloaded OWID data: %v", err)
+		}
+	}
+
+	if !includesAllCountries(allData, countries) {
+		return nil, missingCountriesError(allData, countries)
+	}
+
+	if err := s.Cache.PutWithSource(key, bodyToCache, owidCacheTTL, s.DownloadURL, newETag); err != nil {
+		return nil, err
+	}
+
+	return trimToLast(filterCountries(allData, countries), onlyLast), nil
+}
+
+// download performs a conditional GET against the OWID feed: if etag is
+// non-empty and the upstream still has that ETag, it returns notModified
+// without reading a response body.
+func (s *OWIDSource) download(etag string) (data map[CountryCode]CountryData, newETag string, notModified bool, err error) {
+	fmt.Printf("fetching OWID data from %s ...\n", s.DownloadURL)
+
+	req, err := http.NewRequest(http.MethodGet, s.DownloadURL, nil)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error building request for URL %s: %v", s.DownloadURL, err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error downloading data from URL %s: %v", s.DownloadURL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotModified {
+		return nil, etag, true, nil
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error reading data download response from URL %s: %v", s.DownloadURL, err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", false, fmt.Errorf(
+			"error downloading data from URL %s: expected status %d %s, got %s with body %s",
+			s.DownloadURL, http.StatusOK, http.StatusText(http.StatusOK), response.Status, responseBody)
+	}
+
+	allData, err := decodeOWIDData(responseBody)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	return allData, response.Header.Get("ETag"), false, nil
+}
+
+func decodeOWIDData(data []byte) (map[CountryCode]CountryData, error) {
+	var allData map[CountryCode]CountryData
+	if err := json.Unmarshal(data, &allData); err != nil {
+		return nil, fmt.Errorf("error unmarshaling OWID data: %v", err)
+	}
+	return allData, nil
+}
+
+func includesAllCountries(data map[CountryCode]CountryData, countries map[CountryCode]CountryName) bool {
+	for cc := range countries {
+		if _, ok := data[cc]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func filterCountries(data map[CountryCode]CountryData, countries map[CountryCode]CountryName) map[CountryCode]CountryData {
+	filtered := make(map[CountryCode]CountryData, len(countries))
+	for cc, cd := range data {
+		if _, ok := countries[cc]; ok {
+			filtered[cc] = cd
+		}
+	}
+	return filtered
+}
+
+func missingCountriesError(data map[CountryCode]CountryData, countries map[CountryCode]CountryName) error {
+	var missing []CountryCode
+	for cc := range countries {
+		if _, ok := data[cc]; !ok {
+			missing = append(missing, cc)
+		}
+	}
+	return fmt.Errorf("downloaded data does not contain all the requested countries; missing: %v", missing)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/go-echarts/go-echarts/v2/types"
+)
+
+// metric identifies one of the chartable measures in DayData.
+type metric string
+
+// Supported --metric values.
+const (
+	metricDeaths    metric = "deaths"
+	metricCases     metric = "cases"
+	metricTested    metric = "tested"
+	metricRecovered metric = "recovered"
+)
+
+// metricRenderer describes how a metric is plotted: its chart copy, output
+// file and how to read its value out of a DayData.
+type metricRenderer struct {
+	title    string
+	subtitle string
+	fileName string
+	value    func(DayData) float32
+}
+
+var metricRenderers = map[metric]metricRenderer{
+	metricDeaths: {
+		title:    "Pandemia cu și fără Valuri",
+		subtitle: "Decese zilnice la 1 milion de locuitori",
+		fileName: "pkg/charts/deaths.html",
+		value:    func(d DayData) float32 { return d.NewDeathsPerMillion },
+	},
+	metricCases: {
+		title:    "Pandemia cu și fără Valuri",
+		subtitle: "Cazuri noi zilnice la 1 milion de locuitori",
+		fileName: "pkg/charts/cases.html",
+		value:    func(d DayData) float32 { return d.NewCasesPerMillion },
+	},
+	metricTested: {
+		title:    "Pandemia cu și fără Valuri",
+		subtitle: "Teste zilnice la 1000 de locuitori",
+		fileName: "pkg/charts/tested.html",
+		value:    func(d DayData) float32 { return d.NewTestsPerThousand },
+	},
+	metricRecovered: {
+		title:    "Pandemia cu și fără Valuri",
+		subtitle: "Recuperări totale (cumulat)",
+		fileName: "pkg/charts/recovered.html",
+		value:    func(d DayData) float32 { return d.Recovered },
+	},
+}
+
+// buildMetricChart assembles the go-echarts line chart for a metric, ready
+// to be rendered to any io.Writer.
+func buildMetricChart(
+	m metric, data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName,
+) (*charts.Line, error) {
+	renderer, ok := metricRenderers[m]
+	if !ok {
+		return nil, fmt.Errorf("unknown metric %q", m)
+	}
+
+	chart := charts.NewLine()
+	chart.
+		SetGlobalOptions(
+			charts.WithInitializationOpts(opts.Initialization{Theme: types.ThemeWesteros}),
+			charts.WithTitleOpts(opts.Title{
+				Title:    renderer.title,
+				Subtitle: renderer.subtitle,
+			}),
+			charts.WithLegendOpts(opts.Legend{Show: true}),
+			charts.WithDataZoomOpts(opts.DataZoom{}),
+			charts.WithTooltipOpts(opts.Tooltip{Show: true}),
+		)
+
+	days := make(map[string]int)
+	for _, countryData := range data {
+		for _, d := range countryData.Data {
+			days[d.Date]++
+		}
+	}
+
+	nbCountries := len(data)
+	daysCommonToAllCountries := make([]string, 0, len(days))
+	for day, counter := range days {
+		if counter == nbCountries {
+			daysCommonToAllCountries = append(daysCommonToAllCountries, day)
+		}
+	}
+	sort.Strings(daysCommonToAllCountries)
+	chart.SetXAxis(daysCommonToAllCountries)
+
+	for countryCode, countryData := range data {
+		linesData := make([]opts.LineData, 0, len(countryData.Data))
+		for _, d := range countryData.Data {
+			if days[d.Date] == nbCountries {
+				linesData = append(linesData, opts.LineData{Value: renderer.value(d)})
+			}
+		}
+		chart.AddSeries(string(countryNames[countryCode]), linesData)
+	}
+
+	chart.SetSeriesOptions(
+		charts.WithLineChartOpts(opts.LineChart{Smooth: false}),
+		charts.WithMarkLineNameTypeItemOpts(opts.MarkLineNameTypeItem{
+			Name: "Average",
+			Type: "average",
+		}),
+		charts.WithMarkPointStyleOpts(opts.MarkPointStyle{
+			Label: &opts.Label{
+				Show:      true,
+				Formatter: "{a}: {b}",
+			},
+		}),
+		charts.WithAreaStyleOpts(opts.AreaStyle{
+			Opacity: 0.2,
+		}),
+	)
+
+	return chart, nil
+}
+
+// renderMetricChart renders the given metric for all supplied countries and
+// writes it out as a standalone go-echarts HTML page.
+func renderMetricChart(m metric, data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName) error {
+	renderer, ok := metricRenderers[m]
+	if !ok {
+		return fmt.Errorf("unknown metric %q", m)
+	}
+
+	fmt.Printf("rendering %s chart ...\n", m)
+
+	f, err := os.OpenFile(renderer.fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening chart file for writing: %v", err)
+	}
+	defer f.Close()
+
+	return renderMetricChartTo(m, data, countryNames, f)
+}
+
+// renderMetricChartTo renders the given metric to an arbitrary writer, used
+// by the HTTP server to serve charts without touching disk.
+func renderMetricChartTo(
+	m metric, data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName, w io.Writer,
+) error {
+	chart, err := buildMetricChart(m, data, countryNames)
+	if err != nil {
+		return err
+	}
+	return chart.Render(w)
+}
+
+// renderMetricChartHTML renders the given metric to an in-memory HTML page.
+func renderMetricChartHTML(
+	m metric, data map[CountryCode]CountryData, countryNames map[CountryCode]CountryName,
+) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := renderMetricChartTo(m, data, countryNames, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/padurean/pandemia-covid/pkg/cache"
+)
+
+// cacheTTL is how long a rendered chart is served from cache before a fresh
+// request triggers a re-render. It matches the prefetch cycle below.
+const cacheTTL = time.Hour
+
+// chartRequest is the normalized shape of a /chart/{metric} request, used
+// both as the cache key and to describe what to (re)render.
+type chartRequest struct {
+	Metric    metric   `json:"metric"`
+	Countries []string `json:"countries"`
+	Last      int      `json:"last"`
+}
+
+// key returns a stable digest of the request, used as the cache key. r
+// marshals cleanly to JSON, so the error from RequestKey can't occur here.
+func (r chartRequest) key() string {
+	key, _ := cache.RequestKey(r)
+	return key
+}
+
+type cachedChart struct {
+	html       []byte
+	renderedAt time.Time
+}
+
+// server renders charts on demand over HTTP, caching the most common
+// request shapes and periodically refreshing the ones that peak near the
+// top of the hour.
+type server struct {
+	addr   string
+	source DataSource
+
+	cache sync.Map // key string -> cachedChart
+
+	mu           sync.Mutex
+	peakRequests map[string]chartRequest
+}
+
+func newServer(addr string, source DataSource) *server {
+	return &server{
+		addr:         addr,
+		source:       source,
+		peakRequests: make(map[string]chartRequest),
+	}
+}
+
+// run starts the prefetch goroutine and the HTTP server. It blocks until the
+// server stops.
+func (s *server) run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go s.prefetchLoop(ctx)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chart/", s.handleChart)
+
+	fmt.Printf("serving charts on %s ...\n", s.addr)
+	return http.ListenAndServe(s.addr, mux)
+}
+
+func (s *server) handleChart(w http.ResponseWriter, r *http.Request) {
+	req, err := chartRequestFromURL(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.trackPeakRequest(req)
+
+	key := req.key()
+	if cached, ok := s.cache.Load(key); ok {
+		cc := cached.(cachedChart)
+		if time.Since(cc.renderedAt) < cacheTTL {
+			writeHTML(w, cc.html)
+			return
+		}
+	}
+
+	html, err := s.renderAndCache(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeHTML(w, html)
+}
+
+func chartRequestFromURL(r *http.Request) (chartRequest, error) {
+	m := metric(strings.TrimPrefix(r.URL.Path, "/chart/"))
+	if _, ok := metricRenderers[m]; !ok {
+		return chartRequest{}, fmt.Errorf("unknown metric %q", m)
+	}
+
+	query := r.URL.Query()
+
+	countriesParam := query.Get("countries")
+	if countriesParam == "" {
+		return chartRequest{}, fmt.Errorf("missing required query parameter \"countries\"")
+	}
+
+	var codes []string
+	for _, code := range strings.Split(countriesParam, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	last := 90
+	if lastParam := query.Get("last"); lastParam != "" {
+		n, err := strconv.Atoi(lastParam)
+		if err != nil || n <= 0 {
+			return chartRequest{}, fmt.Errorf("invalid \"last\" query parameter %q", lastParam)
+		}
+		last = n
+	}
+
+	return chartRequest{Metric: m, Countries: codes, Last: last}, nil
+}
+
+// renderAndCache fetches data, renders req's chart and stores the result in
+// the cache, returning the rendered HTML.
+func (s *server) renderAndCache(req chartRequest) ([]byte, error) {
+	names, err := countryNamesForCodes(req.Countries)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := getData(s.source, names, req.Last)
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := renderMetricChartHTML(req.Metric, data, names)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Store(req.key(), cachedChart{html: html, renderedAt: time.Now()})
+
+	return html, nil
+}
+
+// trackPeakRequest remembers requests seen near the top of the hour so the
+// next prefetch cycle knows what to warm.
+func (s *server) trackPeakRequest(req chartRequest) {
+	if time.Now().Minute() < 55 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.peakRequests[req.key()] = req
+}
+
+// prefetchLoop re-renders the requests observed near the top of the previous
+// hour once per hour, so they're already warm in cache by the time the next
+// peak hits.
+func (s *server) prefetchLoop(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.prefetchPeakRequests()
+		}
+	}
+}
+
+func (s *server) prefetchPeakRequests() {
+	s.mu.Lock()
+	requests := make([]chartRequest, 0, len(s.peakRequests))
+	for _, req := range s.peakRequests {
+		requests = append(requests, req)
+	}
+	s.peakRequests = make(map[string]chartRequest)
+	s.mu.Unlock()
+
+	for _, req := range requests {
+		if _, err := s.renderAndCache(req); err != nil {
+			fmt.Printf("error prefetching chart %+v: %v\n", req, err)
+		}
+	}
+}
+
+func writeHTML(w http.ResponseWriter, html []byte) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(html)
+}
@@ -0,0 +1,23 @@
+// Package cache provides a small on-disk cache keyed by source and request,
+// replacing ad-hoc single-file staleness checks with a layout that scales to
+// multiple data sources, metrics and per-country filters.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// RequestKey returns a stable digest of an arbitrary request value (e.g. a
+// struct describing the parameters of a fetch), suitable as the last path
+// segment of a cache key.
+func RequestKey(request interface{}) (string, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling cache request key: %v", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// entryMeta is the sidecar JSON recorded alongside every cached entry.
+//
+// A TTL <= 0 marks the entry as already expired: Get will always report a
+// miss for it (while Raw still returns the bytes for revalidation/fallback).
+// Only the tests pass a non-positive TTL today; it exists so Put can be used to
+// force the next Get to re-fetch without a separate Purge call.
+type entryMeta struct {
+	FetchedAt time.Time     `json:"fetched_at"`
+	TTL       time.Duration `json:"ttl"`
+	SourceURL string        `json:"source_url,omitempty"`
+	ETag      string        `json:"etag,omitempty"`
+}
+
+// FileStore is an on-disk cache keyed by an opaque string, laid out as
+// {BaseDir}/{key}.json with a {BaseDir}/{key}.meta.json sidecar carrying
+// fetch time, source URL, ETag and TTL.
+type FileStore struct {
+	BaseDir string
+}
+
+// NewFileStore returns a FileStore rooted at baseDir, e.g. "pkg/data".
+func NewFileStore(baseDir string) *FileStore {
+	return &FileStore{BaseDir: baseDir}
+}
+
+func (s *FileStore) dataPath(key string) string {
+	return filepath.Join(s.BaseDir, key+".json")
+}
+
+func (s *FileStore) metaPath(key string) string {
+	return filepath.Join(s.BaseDir, key+".meta.json")
+}
+
+// Get returns the cached bytes for key and the time they were stored. ok is
+// false if there is no entry for key, or the entry has expired.
+func (s *FileStore) Get(key string) ([]byte, time.Time, bool) {
+	meta, ok := s.readMeta(key)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	if meta.TTL <= 0 || time.Since(meta.FetchedAt) > meta.TTL {
+		return nil, time.Time{}, false
+	}
+
+	data, ok := s.Raw(key)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+
+	return data, meta.FetchedAt, true
+}
+
+// Raw returns the cached bytes for key regardless of whether the entry has
+// expired, so a caller can still revalidate or fall back to a stale copy.
+func (s *FileStore) Raw(key string) ([]byte, bool) {
+	data, err := os.ReadFile(s.dataPath(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores data under key, valid for ttl.
+func (s *FileStore) Put(key string, data []byte, ttl time.Duration) error {
+	return s.put(key, data, entryMeta{FetchedAt: time.Now(), TTL: ttl})
+}
+
+// PutWithSource is like Put, but also records the upstream URL and ETag so a
+// later refetch can revalidate with If-None-Match instead of re-downloading.
+func (s *FileStore) PutWithSource(key string, data []byte, ttl time.Duration, sourceURL, etag string) error {
+	return s.put(key, data, entryMeta{
+		FetchedAt: time.Now(),
+		TTL:       ttl,
+		SourceURL: sourceURL,
+		ETag:      etag,
+	})
+}
+
+func (s *FileStore) put(key string, data []byte, meta entryMeta) error {
+	dataPath := s.dataPath(key)
+	if err := os.MkdirAll(filepath.Dir(dataPath), 0755); err != nil {
+		return fmt.Errorf("error creating cache directory for key %s: %v", key, err)
+	}
+
+	if err := os.WriteFile(dataPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing cache entry for key %s: %v", key, err)
+	}
+
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("error marshaling cache metadata for key %s: %v", key, err)
+	}
+
+	if err := os.WriteFile(s.metaPath(key), metaBytes, 0644); err != nil {
+		return fmt.Errorf("error writing cache metadata for key %s: %v", key, err)
+	}
+
+	return nil
+}
+
+// Meta returns the recorded source URL and ETag for key, regardless of
+// whether the entry has since expired, so a caller can still revalidate it.
+func (s *FileStore) Meta(key string) (sourceURL, etag string, fetchedAt time.Time, ok bool) {
+	meta, ok := s.readMeta(key)
+	if !ok {
+		return "", "", time.Time{}, false
+	}
+	return meta.SourceURL, meta.ETag, meta.FetchedAt, true
+}
+
+func (s *FileStore) readMeta(key string) (entryMeta, bool) {
+	data, err := os.ReadFile(s.metaPath(key))
+	if err != nil {
+		return entryMeta{}, false
+	}
+
+	var meta entryMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return entryMeta{}, false
+	}
+
+	return meta, true
+}
+
+// Purge removes every cache entry (and its sidecar)
+// whose key starts with prefix.
+func (s *FileStore) Purge(prefix string) error {
+	err := filepath.Walk(s.BaseDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".meta.json") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.BaseDir, path)
+		if err != nil {
+			return err
+		}
+
+		key := strings.TrimSuffix(filepath.ToSlash(rel), ".meta.json")
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.Remove(s.dataPath(key)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error purging cache entries with prefix %q: %v", prefix, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFileStoreGetPutTTL(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	if err := store.Put("owid/2026-07-29/abc", []byte(`{"hello":"world"}`), time.Hour); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	data, _, ok := store.Get("owid/2026-07-29/abc")
+	if !ok {
+		t.Fatal("expected a fresh entry to be found")
+	}
+	if string(data) != `{"hello":"world"}` {
+		t.Fatalf("unexpected data: %s", data)
+	}
+
+	if err := store.Put("owid/2026-07-29/expired", []byte(`{}`), -time.Hour); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, _, ok := store.Get("owid/2026-07-29/expired"); ok {
+		t.Fatal("expected an entry older than its TTL to be treated as missing")
+	}
+	if _, ok := store.Raw("owid/2026-07-29/expired"); !ok {
+		t.Fatal("expected Raw to still return the expired entry's bytes")
+	}
+}
+
+func TestFileStoreMetaWithSource(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	err := store.PutWithSource("owid/2026-07-29/abc", []byte(`{}`), time.Hour, "https://example.com/data.json", `"etag-1"`)
+	if err != nil {
+		t.Fatalf("PutWithSource: %v", err)
+	}
+
+	sourceURL, etag, _, ok := store.Meta("owid/2026-07-29/abc")
+	if !ok {
+		t.Fatal("expected metadata to be found")
+	}
+	if sourceURL != "https://example.com/data.json" {
+		t.Fatalf("unexpected source URL: %s", sourceURL)
+	}
+	if etag != `"etag-1"` {
+		t.Fatalf("unexpected etag: %s", etag)
+	}
+}
+
+func TestFileStorePurgePrefix(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	keys := []string{
+		"owid/2026-07-28/a",
+		"owid/2026-07-29/b",
+		"jhu/2026-07-29/c",
+	}
+	for _, key := range keys {
+		if err := store.Put(key, []byte(`{}`), time.Hour); err != nil {
+			t.Fatalf("Put(%s): %v", key, err)
+		}
+	}
+
+	if err := store.Purge("owid/2026-07-29"); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+
+	if _, _, ok := store.Get("owid/2026-07-28/a"); !ok {
+		t.Error("expected owid/2026-07-28/a to survive the purge")
+	}
+	if _, _, ok := store.Get("owid/2026-07-29/b"); ok {
+		t.Error("expected owid/2026-07-29/b to be purged")
+	}
+	if _, _, ok := store.Get("jhu/2026-07-29/c"); !ok {
+		t.Error("expected jhu/2026-07-29/c to survive the purge")
+	}
+}
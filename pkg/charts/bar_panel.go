@@ -0,0 +1,40 @@
+package charts
+
+import (
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/components"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/go-echarts/go-echarts/v2/types"
+)
+
+// BarPanel renders a stacked bar chart with one series per country, e.g.
+// weekly totals.
+type BarPanel struct {
+	Title    string
+	Subtitle string
+	Series   []Series
+}
+
+// Charts implements Panel.
+func (p BarPanel) Charts() []components.Charter {
+	weeks := commonDates(p.Series)
+
+	bar := charts.NewBar()
+	bar.SetGlobalOptions(
+		charts.WithInitializationOpts(opts.Initialization{Theme: types.ThemeWesteros}),
+		charts.WithTitleOpts(opts.Title{Title: p.Title, Subtitle: p.Subtitle}),
+		charts.WithLegendOpts(opts.Legend{Show: true}),
+		charts.WithTooltipOpts(opts.Tooltip{Show: true}),
+	)
+	bar.SetXAxis(weeks)
+
+	for _, s := range p.Series {
+		data := make([]opts.BarData, 0, len(weeks))
+		for _, week := range weeks {
+			data = append(data, opts.BarData{Value: s.Values[week]})
+		}
+		bar.AddSeries(s.Name, data, charts.WithBarChartOpts(opts.BarChart{Stack: "weekly-totals"}))
+	}
+
+	return []components.Charter{bar}
+}
@@ -0,0 +1,64 @@
+// Package charts renders the pandemia dashboard on top of go-echarts: a
+// Dashboard is a set of Panels, each responsible for producing its own
+// go-echarts component(s), composed into a single templated HTML page.
+package charts
+
+import (
+	"io"
+	"sort"
+
+	"github.com/go-echarts/go-echarts/v2/components"
+)
+
+// Panel contributes one or more go-echarts components to a Dashboard. Most
+// panels contribute exactly one chart; SmallMultiplesPanel contributes one
+// per country.
+type Panel interface {
+	Charts() []components.Charter
+}
+
+// Dashboard composes a set of panels into a single templated HTML page.
+type Dashboard struct {
+	Panels []Panel
+}
+
+// Render writes the full dashboard page to w.
+func (d *Dashboard) Render(w io.Writer) error {
+	page := components.NewPage()
+	page.PageTitle = "Pandemia cu și fără Valuri"
+
+	for _, panel := range d.Panels {
+		page.AddCharts(panel.Charts()...)
+	}
+
+	return page.Render(w)
+}
+
+// Series is one named line/bar of values keyed by date (or week-start date),
+// taken from a DataSource's output either as-is or aggregated by pkg/stats.
+type Series struct {
+	Name   string
+	Values map[string]float32
+}
+
+// commonDates returns the dates present in every series, sorted
+// chronologically, mirroring the "only plot days common to all countries"
+// behaviour of the single-metric charts.
+func commonDates(series []Series) []string {
+	counts := make(map[string]int)
+	for _, s := range series {
+		for date := range s.Values {
+			counts[date]++
+		}
+	}
+
+	dates := make([]string, 0, len(counts))
+	for date, n := range counts {
+		if n == len(series) {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+
+	return dates
+}
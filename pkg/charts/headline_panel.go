@@ -0,0 +1,56 @@
+package charts
+
+import (
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/components"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/go-echarts/go-echarts/v2/types"
+)
+
+// HeadlineTotals is one country's all-time headline numbers.
+type HeadlineTotals struct {
+	Country   string
+	Deaths    float32
+	Confirmed float32
+	Tested    float32
+	Recovered float32
+}
+
+// HeadlinePanel renders the "N Deaths / N Confirmed / N Tested / N
+// Recovered" header block as a grouped bar chart, one group per country.
+type HeadlinePanel struct {
+	Title  string
+	Totals []HeadlineTotals
+}
+
+// Charts implements Panel.
+func (p HeadlinePanel) Charts() []components.Charter {
+	bar := charts.NewBar()
+	bar.SetGlobalOptions(
+		charts.WithInitializationOpts(opts.Initialization{Theme: types.ThemeWesteros}),
+		charts.WithTitleOpts(opts.Title{Title: p.Title, Subtitle: "Totaluri"}),
+		charts.WithLegendOpts(opts.Legend{Show: true}),
+		charts.WithTooltipOpts(opts.Tooltip{Show: true}),
+	)
+
+	countryNames := make([]string, 0, len(p.Totals))
+	for _, t := range p.Totals {
+		countryNames = append(countryNames, t.Country)
+	}
+	bar.SetXAxis(countryNames)
+
+	addSeries := func(name string, value func(HeadlineTotals) float32) {
+		data := make([]opts.BarData, 0, len(p.Totals))
+		for _, t := range p.Totals {
+			data = append(data, opts.BarData{Value: value(t)})
+		}
+		bar.AddSeries(name, data)
+	}
+
+	addSeries("Decese", func(t HeadlineTotals) float32 { return t.Deaths })
+	addSeries("Confirmate", func(t HeadlineTotals) float32 { return t.Confirmed })
+	addSeries("Testate", func(t HeadlineTotals) float32 { return t.Tested })
+	addSeries("Recuperări", func(t HeadlineTotals) float32 { return t.Recovered })
+
+	return []components.Charter{bar}
+}
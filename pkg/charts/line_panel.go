@@ -0,0 +1,66 @@
+package charts
+
+import (
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/components"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/go-echarts/go-echarts/v2/types"
+)
+
+// LinePanel renders a single line chart with one series per country, e.g. a
+// smoothed 7-day rolling average.
+type LinePanel struct {
+	Title    string
+	Subtitle string
+	Series   []Series
+	// Width and Height default to go-echarts' own defaults when empty; set
+	// them to lay out several LinePanels as small multiples.
+	Width  string
+	Height string
+}
+
+// Charts implements Panel.
+func (p LinePanel) Charts() []components.Charter {
+	dates := commonDates(p.Series)
+
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithInitializationOpts(opts.Initialization{
+			Theme:  types.ThemeWesteros,
+			Width:  p.Width,
+			Height: p.Height,
+		}),
+		charts.WithTitleOpts(opts.Title{Title: p.Title, Subtitle: p.Subtitle}),
+		charts.WithLegendOpts(opts.Legend{Show: true}),
+		charts.WithTooltipOpts(opts.Tooltip{Show: true}),
+	)
+	line.SetXAxis(dates)
+
+	for _, s := range p.Series {
+		data := make([]opts.LineData, 0, len(dates))
+		for _, date := range dates {
+			data = append(data, opts.LineData{Value: s.Values[date]})
+		}
+		line.AddSeries(s.Name, data)
+	}
+
+	line.SetSeriesOptions(charts.WithLineChartOpts(opts.LineChart{Smooth: true}))
+
+	return []components.Charter{line}
+}
+
+// SmallMultiplesPanel renders one small LinePanel per country so they can be
+// scanned side by side in a grid.
+type SmallMultiplesPanel struct {
+	Title  string
+	Panels []LinePanel
+}
+
+// Charts implements Panel.
+func (p SmallMultiplesPanel) Charts() []components.Charter {
+	all := make([]components.Charter, 0, len(p.Panels))
+	for _, panel := range p.Panels {
+		all = append(all, panel.Charts()...)
+	}
+	return all
+}
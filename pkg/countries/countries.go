@@ -0,0 +1,50 @@
+// Package countries provides a validated registry of ISO 3166-1 alpha-3
+// country codes with localized display names. The table is generated from
+// upstream CLDR data by gen.go: adding a country means adding it to gen.go's
+// nativeLocales, re-running go generate and rebuilding.
+package countries
+
+//go:generate go run gen.go
+
+// Locale identifies which language a country name is requested in.
+type Locale string
+
+// Locales supported by the generated registry.
+const (
+	LocaleRomanian Locale = "ro"
+	LocaleEnglish  Locale = "en"
+	LocaleNative   Locale = "native"
+)
+
+// Country is a single registry entry: an ISO-3 code and its names across the
+// supported locales.
+type Country struct {
+	Code  string
+	Names map[Locale]string
+}
+
+// CheckCountryCode reports whether code is a known ISO-3 country code.
+func CheckCountryCode(code string) bool {
+	_, ok := registry[code]
+	return ok
+}
+
+// GetCountryCodes returns all known ISO-3 country codes.
+func GetCountryCodes() []string {
+	codes := make([]string, 0, len(registry))
+	for code := range registry {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// GetCountryName returns the display name for code in the given locale. It
+// reports false if the code is unknown or has no name for that locale.
+func GetCountryName(code string, locale Locale) (string, bool) {
+	country, ok := registry[code]
+	if !ok {
+		return "", false
+	}
+	name, ok := country.Names[locale]
+	return name, ok
+}
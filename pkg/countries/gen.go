@@ -0,0 +1,186 @@
+//go:build ignore
+
+// This program regenerates data_generated.go from upstream CLDR JSON
+// territory-name data. Run it via `go generate ./...` from this package.
+//
+// CLDR's per-locale territories.json keys names by ISO 3166-1 alpha-2 (plus
+// a handful of UN M.49 numeric region codes), never by alpha-3, so alpha-2
+// codes are mapped to alpha-3 via CLDR's own supplemental codeMappings.json.
+// A country's "native" name additionally needs to know which locale is
+// native to it; that association isn't itself CLDR data, so it's curated in
+// nativeLocales below, one entry per country carried in data_generated.go.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+)
+
+// cldrTerritoriesURL points at the cldr-localenames-full package, which ships one
+// territories.json per locale under main/<locale>/territories.json.
+const cldrTerritoriesURL = "https://raw.githubusercontent.com/unicode-org/cldr-json/main/cldr-json/cldr-localenames-full/main/%s/territories.json"
+
+// cldrCodeMappingsURL carries the alpha-2/alpha-3/numeric cross-reference
+// CLDR itself uses, so alpha-2 territory keys can be resolved to alpha-3.
+const cldrCodeMappingsURL = "https://raw.githubusercontent.com/unicode-org/cldr-json/main/cldr-json/cldr-core/supplemental/codeMappings.json"
+
+var locales = map[string]string{
+	"ro": "ro",
+	"en": "en",
+}
+
+// nativeLocales maps each alpha-3 code we carry to the CLDR locale its
+// territory name should be read from for LocaleNative.
+var nativeLocales = map[string]string{
+	"ROU": "ro", "DEU": "de", "ITA": "it", "DNK": "da", "NLD": "nl",
+	"NOR": "no", "SWE": "sv", "ISR": "he", "FRA": "fr", "ESP": "es",
+	"PRT": "pt", "GBR": "en", "IRL": "ga", "POL": "pl", "HUN": "hu",
+	"BGR": "bg", "GRC": "el", "AUT": "de", "CHE": "de", "BEL": "nl",
+	"CZE": "cs", "SVK": "sk", "HRV": "hr", "SRB": "sr", "MDA": "ro",
+	"UKR": "uk", "TUR": "tr", "USA": "en", "CAN": "en", "BRA": "pt",
+	"CHN": "zh", "JPN": "ja", "IND": "hi", "AUS": "en", "RUS": "ru",
+}
+
+type territoriesDoc struct {
+	Main map[string]struct {
+		LocaleDisplayNames struct {
+			Territories map[string]string `json:"territories"`
+		} `json:"localeDisplayNames"`
+	} `json:"main"`
+}
+
+type codeMappingsDoc struct {
+	Supplemental struct {
+		CodeMappings map[string]struct {
+			Alpha3 string `json:"_alpha3"`
+		} `json:"codeMappings"`
+	} `json:"supplemental"`
+}
+
+func fetchJSON(url string, v interface{}) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response from %s: %v", url, err)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("error unmarshaling response from %s: %v", url, err)
+	}
+	return nil
+}
+
+// fetchAlpha2To3 returns the alpha-2 -> alpha-3 mapping from CLDR's
+// supplemental code mappings.
+func fetchAlpha2To3() (map[string]string, error) {
+	var doc codeMappingsDoc
+	if err := fetchJSON(cldrCodeMappingsURL, &doc); err != nil {
+		return nil, err
+	}
+
+	alpha2To3 := make(map[string]string, len(doc.Supplemental.CodeMappings))
+	for alpha2, entry := range doc.Supplemental.CodeMappings {
+		if entry.Alpha3 != "" {
+			alpha2To3[alpha2] = entry.Alpha3
+		}
+	}
+	return alpha2To3, nil
+}
+
+// fetchTerritories returns the alpha-2-keyed territory names for locale.
+func fetchTerritories(locale string) (map[string]string, error) {
+	url := fmt.Sprintf(cldrTerritoriesURL, locale)
+
+	var doc territoriesDoc
+	if err := fetchJSON(url, &doc); err != nil {
+		return nil, err
+	}
+
+	for _, localeData := range doc.Main {
+		return localeData.LocaleDisplayNames.Territories, nil
+	}
+
+	return nil, fmt.Errorf("no territory data found in response from %s", url)
+}
+
+// namesByAlpha3 re-keys an alpha-2-keyed territory map to alpha-3 using
+// alpha2To3, dropping any alpha-2 (or numeric region) code with no alpha-3
+// equivalent.
+func namesByAlpha3(territories map[string]string, alpha2To3 map[string]string) map[string]string {
+	out := make(map[string]string, len(territories))
+	for alpha2, name := range territories {
+		alpha3, ok := alpha2To3[alpha2]
+		if !ok {
+			continue
+		}
+		out[alpha3] = name
+	}
+	return out
+}
+
+func main() {
+	alpha2To3, err := fetchAlpha2To3()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	namesByLocale := make(map[string]map[string]string, len(locales))
+	for locale := range locales {
+		territories, err := fetchTerritories(locale)
+		if err != nil {
+			log.Fatal(err)
+		}
+		namesByLocale[locale] = namesByAlpha3(territories, alpha2To3)
+	}
+
+	nativeTerritoriesByLocale := make(map[string]map[string]string)
+	nativeNames := make(map[string]string, len(nativeLocales))
+	for code, locale := range nativeLocales {
+		territories, ok := nativeTerritoriesByLocale[locale]
+		if !ok {
+			fetched, err := fetchTerritories(locale)
+			if err != nil {
+				log.Fatal(err)
+			}
+			territories = namesByAlpha3(fetched, alpha2To3)
+			nativeTerritoriesByLocale[locale] = territories
+		}
+		if name, ok := territories[code]; ok {
+			nativeNames[code] = name
+		}
+	}
+
+	codes := make([]string, 0, len(nativeLocales))
+	for code := range nativeLocales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	f, err := os.Create("data_generated.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	fmt.Fprintln(f, "// Code generated by gen.go from CLDR; DO NOT EDIT.")
+	fmt.Fprintln(f, "// To regenerate, run: go generate ./...")
+	fmt.Fprintln(f)
+	fmt.Fprintln(f, "package countries")
+	fmt.Fprintln(f)
+	fmt.Fprintln(f, "var registry = map[string]Country{")
+	for _, code := range codes {
+		fmt.Fprintf(f, "\t%q: {Code: %q, Names: map[Locale]string{LocaleRomanian: %q, LocaleEnglish: %q, LocaleNative: %q}},\n",
+			code, code, namesByLocale["ro"][code], namesByLocale["en"][code], nativeNames[code])
+	}
+	fmt.Fprintln(f, "}")
+}
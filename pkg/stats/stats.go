@@ -0,0 +1,53 @@
+// Package stats computes the aggregates the dashboard panels plot, so the
+// panels themselves stay pure presentation.
+package stats
+
+// RollingAverage returns the trailing moving average of values over window
+// points. Before window points are available it averages whatever has been
+// seen so far, so the output is always the same length as the input.
+func RollingAverage(values []float32, window int) []float32 {
+	if window < 1 {
+		window = 1
+	}
+
+	out := make([]float32, len(values))
+
+	var sum float32
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+
+		n := window
+		if i+1 < window {
+			n = i + 1
+		}
+		out[i] = sum / float32(n)
+	}
+
+	return out
+}
+
+// WeekBucket is one week's worth of aggregated totals, labeled by the date
+// of the first day in the bucket.
+type WeekBucket struct {
+	WeekStart string
+	Total     float32
+}
+
+// WeeklyTotals sums values into consecutive 7-day buckets starting from the
+// first date in dates. dates and values must be the same length and sorted
+// chronologically.
+func WeeklyTotals(dates []string, values []float32) []WeekBucket {
+	var buckets []WeekBucket
+
+	for i, v := range values {
+		if i%7 == 0 {
+			buckets = append(buckets, WeekBucket{WeekStart: dates[i]})
+		}
+		buckets[len(buckets)-1].Total += v
+	}
+
+	return buckets
+}